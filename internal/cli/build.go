@@ -10,8 +10,10 @@ import (
 	"os/exec"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/answerdev/answer/pkg/dir"
@@ -59,6 +61,30 @@ type buildingMaterial struct {
 	outputPath              string
 	tmpDir                  string
 	originalAnswerInfo      OriginalAnswerInfo
+
+	// frozenLockfile makes the build fail instead of proceeding when answer.sum is
+	// missing or stale. Set by `answer build --frozen`.
+	frozenLockfile bool
+	// updateLockfile re-resolves every plugin to its latest allowed version instead of
+	// pinning to answer.sum. Set by `answer build --update`.
+	updateLockfile bool
+	// resolvedLockfile is populated by verifyPluginLockfile and written out by
+	// updatePluginLockfile once the build succeeds.
+	resolvedLockfile *lockfile
+
+	// logFormat and buildReportPath back `answer build --log-format` and
+	// `answer build --build-report`.
+	logFormat       LogFormat
+	buildReportPath string
+	// strictI18n makes mergeI18nFiles fail the build on a translation key conflict
+	// instead of only warning. Set by `answer build --strict-i18n`.
+	strictI18n bool
+	// report accumulates a StageResult per DoTask call.
+	report *BuildReport
+	// currentStage and stageOutput are set by DoTask for the duration of each stage, so
+	// newExecCmd can annotate and capture subprocess output for the report.
+	currentStage string
+	stageOutput  *bytes.Buffer
 }
 
 type OriginalAnswerInfo struct {
@@ -74,9 +100,35 @@ type pluginInfo struct {
 	Path string
 	// Version of the plugin
 	Version string
+
+	// GitURL, GitRef and GitSubdir are set for plugins given as
+	// `git+https://host/org/repo.git@<ref>[#subdir]`. cloneGitPlugins resolves them into
+	// Name and Path before the rest of the pipeline runs.
+	GitURL    string
+	GitRef    string
+	GitSubdir string
+}
+
+// BuildOptions controls optional behaviour of BuildNewAnswer beyond the base plugin set.
+type BuildOptions struct {
+	// Frozen fails the build if any plugin is missing from answer.sum or its checksum
+	// no longer matches. Corresponds to `answer build --frozen`.
+	Frozen bool
+	// Update re-resolves every plugin to its latest allowed version and refreshes
+	// answer.sum instead of pinning to it. Corresponds to `answer build --update`.
+	Update bool
+	// LogFormat selects how the build report is rendered (`answer build --log-format`).
+	// Defaults to LogFormatText.
+	LogFormat LogFormat
+	// BuildReportPath, if set, writes the rendered build report to that path
+	// (`answer build --build-report=path`).
+	BuildReportPath string
+	// StrictI18n fails the build on a translation key conflict between plugins instead
+	// of just warning about it. Corresponds to `answer build --strict-i18n`.
+	StrictI18n bool
 }
 
-func newAnswerBuilder(outputPath string, plugins []string, originalAnswerInfo OriginalAnswerInfo) *answerBuilder {
+func newAnswerBuilder(outputPath string, plugins []string, originalAnswerInfo OriginalAnswerInfo, opts BuildOptions) *answerBuilder {
 	material := &buildingMaterial{originalAnswerInfo: originalAnswerInfo}
 	parentDir, _ := filepath.Abs(".")
 	material.tmpDir, _ = os.MkdirTemp(parentDir, "answer_build")
@@ -86,33 +138,68 @@ func newAnswerBuilder(outputPath string, plugins []string, originalAnswerInfo Or
 	material.outputPath = outputPath
 	material.plugins = formatPlugins(plugins)
 	material.answerModuleReplacement = os.Getenv("ANSWER_MODULE")
+	material.frozenLockfile = opts.Frozen
+	material.updateLockfile = opts.Update
+	material.logFormat = opts.LogFormat
+	material.buildReportPath = opts.BuildReportPath
+	material.strictI18n = opts.StrictI18n
+	material.report = &BuildReport{PluginVersions: make(map[string]string)}
 	return &answerBuilder{
 		buildingMaterial: material,
 	}
 }
 
-func (a *answerBuilder) DoTask(task func(b *buildingMaterial) error) {
+// DoTask runs a named pipeline stage, recording its timing, exit code, and captured
+// subprocess output onto buildingMaterial.report. It is a no-op once a prior stage has
+// failed.
+func (a *answerBuilder) DoTask(stage string, task func(b *buildingMaterial) error) {
 	if a.BuildError != nil {
 		return
 	}
-	a.BuildError = task(a.buildingMaterial)
+	b := a.buildingMaterial
+	b.currentStage = stage
+	b.stageOutput = &bytes.Buffer{}
+
+	result := StageResult{Stage: stage, Start: time.Now()}
+	err := task(b)
+	result.End = time.Now()
+	result.Output = b.stageOutput.String()
+	result.ExitCode = exitCodeOf(err)
+	if err != nil {
+		result.Error = err.Error()
+	}
+	if b.report != nil {
+		b.report.Stages = append(b.report.Stages, result)
+	}
+	a.BuildError = err
 }
 
 // BuildNewAnswer builds a new answer with specified plugins
-func BuildNewAnswer(outputPath string, plugins []string, originalAnswerInfo OriginalAnswerInfo) (err error) {
-	builder := newAnswerBuilder(outputPath, plugins, originalAnswerInfo)
-	builder.DoTask(createMainGoFile)
-	builder.DoTask(downloadGoModFile)
-	builder.DoTask(mergeI18nFiles)
-	builder.DoTask(replaceNecessaryFile)
-	builder.DoTask(buildBinary)
-	builder.DoTask(cleanByproduct)
+func BuildNewAnswer(outputPath string, plugins []string, originalAnswerInfo OriginalAnswerInfo, opts BuildOptions) (err error) {
+	builder := newAnswerBuilder(outputPath, plugins, originalAnswerInfo, opts)
+	builder.DoTask("cloneGitPlugins", cloneGitPlugins)
+	builder.DoTask("verifyPluginLockfile", verifyPluginLockfile)
+	builder.DoTask("createMainGoFile", createMainGoFile)
+	builder.DoTask("downloadGoModFile", downloadGoModFile)
+	builder.DoTask("mergeI18nFiles", mergeI18nFiles)
+	builder.DoTask("replaceNecessaryFile", replaceNecessaryFile)
+	builder.DoTask("buildBinary", buildBinary)
+	builder.DoTask("updatePluginLockfile", updatePluginLockfile)
+	builder.DoTask("cleanByproduct", cleanByproduct)
+
+	recordPluginVersions(builder.buildingMaterial)
+	writeBuildReport(builder.buildingMaterial)
 	return builder.BuildError
 }
 
 func formatPlugins(plugins []string) (formatted []*pluginInfo) {
 	for _, plugin := range plugins {
 		plugin = strings.TrimSpace(plugin)
+		// a git-sourced plugin looks like 'git+https://host/org/repo.git@<ref>[#subdir]'
+		if strings.HasPrefix(plugin, "git+") {
+			formatted = append(formatted, parseGitPluginSource(plugin))
+			continue
+		}
 		// plugin description like this 'github.com/answerdev/github-connector@latest=/local/path'
 		info := &pluginInfo{}
 		plugin, info.Path, _ = strings.Cut(plugin, "=")
@@ -200,14 +287,27 @@ func replaceNecessaryFile(b *buildingMaterial) (err error) {
 	return err
 }
 
+// i18nContribution is the translation subtree one plugin contributed for one of its
+// top-level `plugin.<slug>` keys in one i18n file, tracked so mergeI18nFiles can detect
+// when a second plugin tries to contribute under the same key.
+type i18nContribution struct {
+	plugin string
+	value  *yaml.Node
+}
+
+// mergeI18nFiles deep-merges every plugin's i18n/<file>.yaml `plugin.<slug>.*` subtree
+// into the corresponding original Answer i18n file, parsing and rewriting it as a single
+// yaml.Node tree so key ordering is preserved. Each plugin must declare its translations
+// under its own slug (derived from its module path); a second plugin contributing under
+// a slug already claimed by another plugin is reported as
+// `[i18n] conflict: key=... pluginA=... pluginB=...` and, under --strict-i18n, fails the
+// build.
 func mergeI18nFiles(b *buildingMaterial) (err error) {
 	fmt.Printf("try to merge i18n files\n")
 
-	type YamlPluginContent struct {
-		Plugin map[string]any `yaml:"plugin"`
-	}
+	// fileContribs[filename][slug] is the contribution claiming that slug in that file.
+	fileContribs := make(map[string]map[string]i18nContribution)
 
-	pluginAllTranslations := make(map[string]*YamlPluginContent)
 	for _, plugin := range b.plugins {
 		i18nDir := filepath.Join(b.tmpDir, fmt.Sprintf("vendor/%s/i18n", plugin.Name))
 		fmt.Println("i18n dir: ", i18nDir)
@@ -220,6 +320,8 @@ func mergeI18nFiles(b *buildingMaterial) (err error) {
 			return err
 		}
 
+		expectedSlug := pluginSlug(plugin.Name)
+
 		for _, file := range entries {
 			// ignore directory
 			if file.IsDir() {
@@ -235,17 +337,38 @@ func mergeI18nFiles(b *buildingMaterial) (err error) {
 				continue
 			}
 
-			translation := &YamlPluginContent{}
-			if err = yaml.Unmarshal(buf, translation); err != nil {
+			var doc yaml.Node
+			if err = yaml.Unmarshal(buf, &doc); err != nil {
 				log.Debugf("unmarshal translation file failed: %s %s", file.Name(), err)
 				continue
 			}
 
-			if pluginAllTranslations[file.Name()] == nil {
-				pluginAllTranslations[file.Name()] = &YamlPluginContent{Plugin: make(map[string]any)}
+			pluginNode := findMapValue(&doc, "plugin")
+			if pluginNode == nil || pluginNode.Kind != yaml.MappingNode {
+				continue
+			}
+
+			if fileContribs[file.Name()] == nil {
+				fileContribs[file.Name()] = make(map[string]i18nContribution)
 			}
-			for k, v := range translation.Plugin {
-				pluginAllTranslations[file.Name()].Plugin[k] = v
+
+			for i := 0; i+1 < len(pluginNode.Content); i += 2 {
+				slug := pluginNode.Content[i].Value
+				value := pluginNode.Content[i+1]
+
+				if slug != expectedSlug {
+					return fmt.Errorf("[i18n] %s declares translations under plugin.%s, expected its own slug plugin.%s",
+						plugin.Name, slug, expectedSlug)
+				}
+
+				if existing, ok := fileContribs[file.Name()][slug]; ok && existing.plugin != plugin.Name {
+					msg := fmt.Sprintf("[i18n] conflict: key=%s pluginA=%s pluginB=%s", slug, existing.plugin, plugin.Name)
+					if b.strictI18n {
+						return fmt.Errorf("%s", msg)
+					}
+					fmt.Println(msg)
+				}
+				fileContribs[file.Name()][slug] = i18nContribution{plugin: plugin.Name, value: value}
 			}
 		}
 	}
@@ -267,24 +390,105 @@ func mergeI18nFiles(b *buildingMaterial) (err error) {
 			continue
 		}
 
-		// if plugin don't have this translation file, ignore it
-		if pluginAllTranslations[filename] == nil {
+		// if no plugin has translations for this file, leave it untouched
+		contribs := fileContribs[filename]
+		if len(contribs) == 0 {
 			continue
 		}
 
-		out, _ := yaml.Marshal(pluginAllTranslations[filename])
-
-		buf, err := os.OpenFile(filepath.Join(originalI18nDir, filename), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		originalPath := filepath.Join(originalI18nDir, filename)
+		buf, err := os.ReadFile(originalPath)
 		if err != nil {
 			log.Debugf("read translation file failed: %s %s", filename, err)
 			continue
 		}
 
-		_, _ = buf.WriteString("\n")
-		_, _ = buf.Write(out)
-		_ = buf.Close()
+		var doc yaml.Node
+		if err = yaml.Unmarshal(buf, &doc); err != nil {
+			return fmt.Errorf("parse %s: %w", filename, err)
+		}
+		if doc.Kind == 0 {
+			doc.Kind = yaml.DocumentNode
+		}
+		if len(doc.Content) == 0 {
+			doc.Content = []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}
+		}
+		root := doc.Content[0]
+
+		pluginNode := findOrCreateMapValue(root, "plugin")
+
+		slugs := make([]string, 0, len(contribs))
+		for slug := range contribs {
+			slugs = append(slugs, slug)
+		}
+		sort.Strings(slugs)
+		for _, slug := range slugs {
+			setMapValue(pluginNode, slug, contribs[slug].value)
+		}
+
+		out, err := yaml.Marshal(&doc)
+		if err != nil {
+			return fmt.Errorf("marshal %s: %w", filename, err)
+		}
+		if err = os.WriteFile(originalPath, out, 0644); err != nil {
+			log.Debugf("write translation file failed: %s %s", filename, err)
+			continue
+		}
 	}
-	return err
+	return nil
+}
+
+// findMapValue looks up key in a YAML document or mapping node, returning the value
+// node or nil if it isn't present.
+func findMapValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			return nil
+		}
+		return findMapValue(node.Content[0], key)
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// findOrCreateMapValue is like findMapValue but appends a new empty mapping under key
+// (preserving the existing key order) when it isn't already present.
+func findOrCreateMapValue(mapping *yaml.Node, key string) *yaml.Node {
+	if existing := findMapValue(mapping, key); existing != nil {
+		return existing
+	}
+	keyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}
+	valNode := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	mapping.Content = append(mapping.Content, keyNode, valNode)
+	return valNode
+}
+
+// setMapValue sets key to value within mapping, appending a new entry if key is absent.
+// When both the existing value and the new one are mappings they are merged recursively;
+// otherwise the existing entry is replaced in place.
+func setMapValue(mapping *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			existing := mapping.Content[i+1]
+			if existing.Kind == yaml.MappingNode && value.Kind == yaml.MappingNode {
+				for j := 0; j+1 < len(value.Content); j += 2 {
+					setMapValue(existing, value.Content[j].Value, value.Content[j+1])
+				}
+				return
+			}
+			mapping.Content[i+1] = value
+			return
+		}
+	}
+	keyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}
+	mapping.Content = append(mapping.Content, keyNode, value)
 }
 
 func copyDirEntries(sourceFs embed.FS, sourceDir string, targetDir string) (err error) {
@@ -359,10 +563,11 @@ func cleanByproduct(b *buildingMaterial) (err error) {
 
 func (b *buildingMaterial) newExecCmd(command string, args ...string) *exec.Cmd {
 	cmd := exec.Command(command, args...)
-	fmt.Println(cmd.Args)
+	fmt.Printf("[%s] %v\n", b.currentStage, cmd.Args)
 	cmd.Dir = b.tmpDir
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	tee := &stagePrefixWriter{stage: b.currentStage, under: os.Stdout, capture: b.stageOutput}
+	cmd.Stdout = tee
+	cmd.Stderr = tee
 	return cmd
 }
 
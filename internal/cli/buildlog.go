@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/answerdev/answer/pkg/writer"
+)
+
+// LogFormat selects how a BuildReport is rendered.
+type LogFormat string
+
+const (
+	LogFormatText LogFormat = "text"
+	LogFormatJSON LogFormat = "json"
+)
+
+// StageResult records timing, exit status, and captured output for one DoTask stage.
+type StageResult struct {
+	Stage    string    `json:"stage"`
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	ExitCode int       `json:"exit_code"`
+	Error    string    `json:"error,omitempty"`
+	Output   string    `json:"output,omitempty"`
+}
+
+// BuildReport is the machine-readable trace of a BuildNewAnswer run: every stage's
+// timing and subprocess output, plus the plugin versions actually resolved.
+type BuildReport struct {
+	Stages         []StageResult     `json:"stages"`
+	PluginVersions map[string]string `json:"plugin_versions"`
+}
+
+// Render formats the report as either a json or a text document.
+func (r *BuildReport) Render(format LogFormat) string {
+	if format == LogFormatJSON {
+		out, err := json.MarshalIndent(r, "", "  ")
+		if err != nil {
+			return fmt.Sprintf(`{"error": %q}`, err.Error())
+		}
+		return string(out)
+	}
+
+	var b strings.Builder
+	for _, s := range r.Stages {
+		status := "ok"
+		if len(s.Error) > 0 {
+			status = "failed"
+		}
+		fmt.Fprintf(&b, "%-24s %-6s %6dms exit=%d\n", s.Stage, status, s.End.Sub(s.Start).Milliseconds(), s.ExitCode)
+		if len(s.Error) > 0 {
+			fmt.Fprintf(&b, "  error: %s\n", s.Error)
+		}
+	}
+	for name, version := range r.PluginVersions {
+		fmt.Fprintf(&b, "plugin %s@%s\n", name, version)
+	}
+	return b.String()
+}
+
+// exitCodeOf extracts the subprocess exit code from an error returned by exec.Cmd.Run,
+// defaulting to 1 for any other non-nil error and 0 for nil.
+func exitCodeOf(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return 1
+}
+
+// stagePrefixWriter tees subprocess output to an underlying writer (annotating each
+// line with its build stage) and to a capture buffer recorded on the BuildReport.
+type stagePrefixWriter struct {
+	stage   string
+	under   io.Writer
+	capture *bytes.Buffer
+	pending []byte
+}
+
+func (w *stagePrefixWriter) Write(p []byte) (int, error) {
+	if w.capture != nil {
+		w.capture.Write(p)
+	}
+	w.pending = append(w.pending, p...)
+	for {
+		idx := bytes.IndexByte(w.pending, '\n')
+		if idx < 0 {
+			break
+		}
+		fmt.Fprintf(w.under, "[%s] %s\n", w.stage, w.pending[:idx])
+		w.pending = w.pending[idx+1:]
+	}
+	return len(p), nil
+}
+
+// recordPluginVersions snapshots the resolved version of every plugin into the build
+// report. Called unconditionally after the pipeline runs, even on failure, so partial
+// reports still show what was resolved before the error.
+func recordPluginVersions(b *buildingMaterial) {
+	if b.report == nil {
+		return
+	}
+	for _, p := range b.plugins {
+		b.report.PluginVersions[p.Name] = p.Version
+	}
+}
+
+// writeBuildReport renders the build report in b.logFormat, prints it, and additionally
+// writes it to b.buildReportPath when one was given (`answer build --build-report=path`).
+func writeBuildReport(b *buildingMaterial) {
+	if b.report == nil {
+		return
+	}
+	format := b.logFormat
+	if len(format) == 0 {
+		format = LogFormatText
+	}
+	rendered := b.report.Render(format)
+	fmt.Println(rendered)
+
+	if len(b.buildReportPath) == 0 {
+		return
+	}
+	if err := writer.WriteFile(b.buildReportPath, rendered); err != nil {
+		fmt.Printf("[build] failed to write build report to %s: %s\n", b.buildReportPath, err)
+	}
+}
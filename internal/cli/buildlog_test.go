@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildReport_RenderJSON(t *testing.T) {
+	r := &BuildReport{
+		Stages:         []StageResult{{Stage: "buildBinary", ExitCode: 0}},
+		PluginVersions: map[string]string{"github.com/answerdev/github-connector": "v1.0.0"},
+	}
+	out := r.Render(LogFormatJSON)
+	if !strings.Contains(out, `"stage": "buildBinary"`) {
+		t.Errorf("Render(LogFormatJSON) = %s, want it to contain the stage name", out)
+	}
+}
+
+func TestBuildReport_RenderText(t *testing.T) {
+	start := time.Unix(0, 0)
+	r := &BuildReport{
+		Stages: []StageResult{
+			{Stage: "buildBinary", Start: start, End: start.Add(250 * time.Millisecond), ExitCode: 0},
+			{Stage: "mergeI18nFiles", Start: start, End: start, ExitCode: 1, Error: "boom"},
+		},
+		PluginVersions: map[string]string{"github.com/answerdev/github-connector": "v1.0.0"},
+	}
+	out := r.Render(LogFormatText)
+	if !strings.Contains(out, "buildBinary") || !strings.Contains(out, "250ms") {
+		t.Errorf("Render(LogFormatText) = %q, want the stage name and duration", out)
+	}
+	if !strings.Contains(out, "error: boom") {
+		t.Errorf("Render(LogFormatText) = %q, want the failed stage's error", out)
+	}
+	if !strings.Contains(out, "plugin github.com/answerdev/github-connector@v1.0.0") {
+		t.Errorf("Render(LogFormatText) = %q, want the resolved plugin version", out)
+	}
+}
+
+func TestExitCodeOf(t *testing.T) {
+	if got := exitCodeOf(nil); got != 0 {
+		t.Errorf("exitCodeOf(nil) = %d, want 0", got)
+	}
+	if got := exitCodeOf(errors.New("boom")); got != 1 {
+		t.Errorf("exitCodeOf(non-exec error) = %d, want 1", got)
+	}
+
+	cmd := exec.Command("sh", "-c", "exit 3")
+	err := cmd.Run()
+	if got := exitCodeOf(err); got != 3 {
+		t.Errorf("exitCodeOf(exec.ExitError) = %d, want 3", got)
+	}
+}
+
+func TestStagePrefixWriter_PrefixesCompleteLinesAndCaptures(t *testing.T) {
+	under := &bytes.Buffer{}
+	capture := &bytes.Buffer{}
+	w := &stagePrefixWriter{stage: "buildBinary", under: under, capture: capture}
+
+	if _, err := w.Write([]byte("line one\nline two")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if _, err := w.Write([]byte(" continued\n")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	wantUnder := "[buildBinary] line one\n[buildBinary] line two continued\n"
+	if under.String() != wantUnder {
+		t.Errorf("under.String() = %q, want %q", under.String(), wantUnder)
+	}
+	wantCapture := "line one\nline two continued\n"
+	if capture.String() != wantCapture {
+		t.Errorf("capture.String() = %q, want %q", capture.String(), wantCapture)
+	}
+}
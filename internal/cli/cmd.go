@@ -0,0 +1,147 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// NewRootCmd builds the `answer` command tree, wiring the plugin-authoring and
+// build-pipeline features in this package to user-facing flags and subcommands.
+func NewRootCmd() *cobra.Command {
+	root := &cobra.Command{
+		Use:   "answer",
+		Short: "Build and extend the Answer Q&A platform",
+	}
+	root.AddCommand(newPluginCmd())
+	root.AddCommand(newBuildCmd())
+	return root
+}
+
+// newPluginCmd registers the `answer plugin` command group.
+func newPluginCmd() *cobra.Command {
+	pluginCmd := &cobra.Command{
+		Use:   "plugin",
+		Short: "Manage answer plugins",
+	}
+	pluginCmd.AddCommand(newPluginInitCmd())
+	return pluginCmd
+}
+
+// newPluginInitCmd implements `answer plugin init`.
+func newPluginInitCmd() *cobra.Command {
+	var (
+		modulePath string
+		kind       string
+	)
+	cmd := &cobra.Command{
+		Use:   "init [output-dir]",
+		Short: "Scaffold a new answer plugin project",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputDir := "."
+			if len(args) > 0 {
+				outputDir = args[0]
+			}
+			return InitPlugin(outputDir, PluginInitOptions{
+				ModulePath: modulePath,
+				Kind:       PluginKind(kind),
+			})
+		},
+	}
+	cmd.Flags().StringVar(&modulePath, "module", "", "Go module path of the new plugin, e.g. github.com/you/my-plugin")
+	cmd.Flags().StringVar(&kind, "kind", string(PluginKindConnector), "plugin extension point: connector, filter, storage, search, or notification")
+	_ = cmd.MarkFlagRequired("module")
+	return cmd
+}
+
+// newBuildCmd implements `answer build`, including its --frozen/--update,
+// --watch/--exec, and --release variants.
+func newBuildCmd() *cobra.Command {
+	var (
+		outputPath  string
+		frozen      bool
+		update      bool
+		strictI18n  bool
+		logFormat   string
+		buildReport string
+		watch       bool
+		execAfter   bool
+		release     bool
+		targets     []string
+		outputDir   string
+		signKeyPath string
+	)
+	cmd := &cobra.Command{
+		Use:   "build [plugin ...]",
+		Short: "Build a new answer binary with the given plugins",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			plugins := args
+			var execArgs []string
+			if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+				plugins, execArgs = args[:dash], args[dash:]
+			}
+
+			opts := BuildOptions{
+				Frozen:          frozen,
+				Update:          update,
+				LogFormat:       LogFormat(logFormat),
+				BuildReportPath: buildReport,
+				StrictI18n:      strictI18n,
+			}
+			var originalAnswerInfo OriginalAnswerInfo
+
+			if release {
+				releaseTargets, err := parseReleaseTargets(targets)
+				if err != nil {
+					return err
+				}
+				_, err = BuildReleaseArtifacts(plugins, originalAnswerInfo, opts, ReleaseOptions{
+					Targets:     releaseTargets,
+					OutputDir:   outputDir,
+					SignKeyPath: signKeyPath,
+				})
+				return err
+			}
+
+			if watch {
+				return BuildNewAnswerWatch(outputPath, plugins, originalAnswerInfo, opts, WatchOptions{
+					Exec: execAfter,
+					Args: execArgs,
+				})
+			}
+
+			return BuildNewAnswer(outputPath, plugins, originalAnswerInfo, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputPath, "output", "o", "", "path to write the built answer binary to")
+	cmd.Flags().BoolVar(&frozen, "frozen", false, "fail the build if a plugin is missing from answer.sum or its checksum no longer matches")
+	cmd.Flags().BoolVar(&update, "update", false, "re-resolve every plugin to its latest allowed version and refresh answer.sum")
+	cmd.Flags().BoolVar(&strictI18n, "strict-i18n", false, "fail the build on a translation key conflict between plugins instead of warning")
+	cmd.Flags().StringVar(&logFormat, "log-format", string(LogFormatText), "build report format: text or json")
+	cmd.Flags().StringVar(&buildReport, "build-report", "", "write the rendered build report to this path")
+	cmd.Flags().BoolVar(&watch, "watch", false, "rebuild automatically when a path-replaced plugin's files change")
+	cmd.Flags().BoolVar(&execAfter, "exec", false, "run the built binary after each successful build (only with --watch)")
+	cmd.Flags().BoolVar(&release, "release", false, "cross-compile and package release artifacts instead of a single binary")
+	cmd.Flags().StringSliceVar(&targets, "targets", nil, "GOOS/GOARCH pairs to build for with --release, e.g. linux/amd64,darwin/arm64")
+	cmd.Flags().StringVar(&outputDir, "output-dir", "dist", "directory to write release archives and SHA256SUMS to (only with --release)")
+	cmd.Flags().StringVar(&signKeyPath, "sign-key", "", "path to a raw ed25519 private key used to sign SHA256SUMS (only with --release)")
+
+	return cmd
+}
+
+// parseReleaseTargets parses `--targets` entries of the form "GOOS/GOARCH" into
+// ReleaseTargets.
+func parseReleaseTargets(raw []string) ([]ReleaseTarget, error) {
+	targets := make([]ReleaseTarget, 0, len(raw))
+	for _, r := range raw {
+		goos, goarch, ok := strings.Cut(r, "/")
+		if !ok {
+			return nil, fmt.Errorf("invalid --targets entry %q, want GOOS/GOARCH", r)
+		}
+		targets = append(targets, ReleaseTarget{GOOS: goos, GOARCH: goarch})
+	}
+	return targets, nil
+}
@@ -0,0 +1,25 @@
+package cli
+
+import "testing"
+
+func TestParseReleaseTargets(t *testing.T) {
+	targets, err := parseReleaseTargets([]string{"linux/amd64", "darwin/arm64"})
+	if err != nil {
+		t.Fatalf("parseReleaseTargets() error = %v", err)
+	}
+	want := []ReleaseTarget{{GOOS: "linux", GOARCH: "amd64"}, {GOOS: "darwin", GOARCH: "arm64"}}
+	if len(targets) != len(want) {
+		t.Fatalf("parseReleaseTargets() = %+v, want %+v", targets, want)
+	}
+	for i, w := range want {
+		if targets[i] != w {
+			t.Errorf("parseReleaseTargets()[%d] = %+v, want %+v", i, targets[i], w)
+		}
+	}
+}
+
+func TestParseReleaseTargets_RejectsMissingSlash(t *testing.T) {
+	if _, err := parseReleaseTargets([]string{"linux-amd64"}); err == nil {
+		t.Fatal("expected an error for a --targets entry without a GOOS/GOARCH separator")
+	}
+}
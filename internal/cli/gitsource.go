@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// parseGitPluginSource parses a `git+https://host/org/repo.git@<ref>[#subdir]` plugin
+// source into a pluginInfo. Name and Path are left empty here; cloneGitPlugins fills
+// them in once the repository has been checked out and its go.mod read.
+func parseGitPluginSource(raw string) *pluginInfo {
+	src := strings.TrimPrefix(raw, "git+")
+	src, subdir, _ := strings.Cut(src, "#")
+	url, ref, _ := strings.Cut(src, "@")
+	return &pluginInfo{
+		GitURL:    url,
+		GitRef:    ref,
+		GitSubdir: subdir,
+	}
+}
+
+// cloneGitPlugins clones every git-sourced plugin into buildingMaterial.tmpDir (see
+// cloneGitRef for when the clone is shallow),
+// resolves its module path from the cloned go.mod, and rewrites the plugin entry so the
+// rest of the pipeline treats it like any other path-replaced plugin.
+func cloneGitPlugins(b *buildingMaterial) (err error) {
+	for _, p := range b.plugins {
+		if len(p.GitURL) == 0 {
+			continue
+		}
+
+		fmt.Printf("[build] cloning %s @ %s\n", p.GitURL, p.GitRef)
+		cloneDir := filepath.Join(b.tmpDir, "git-plugins", gitCloneDirName(p.GitURL))
+		if err = cloneGitRef(cloneDir, p.GitURL, p.GitRef); err != nil {
+			return fmt.Errorf("clone %s@%s: %w", p.GitURL, p.GitRef, err)
+		}
+
+		modDir := cloneDir
+		if len(p.GitSubdir) > 0 {
+			modDir = filepath.Join(cloneDir, p.GitSubdir)
+		}
+
+		p.Name, err = readGoModModulePath(filepath.Join(modDir, "go.mod"))
+		if err != nil {
+			return fmt.Errorf("resolve module path for %s: %w", p.GitURL, err)
+		}
+		p.Path = modDir
+		if len(p.Version) == 0 {
+			p.Version = gitPseudoVersion(p.GitRef)
+		}
+	}
+	return nil
+}
+
+// cloneGitRef performs a shallow, single-branch clone of url at ref. It first assumes
+// ref is a branch name; if that fails (e.g. ref is a tag or a commit hash) it falls back
+// to a full clone (all branches and tags, no depth limit) followed by an explicit
+// checkout of ref.
+func cloneGitRef(dir, url, ref string) error {
+	_, err := git.PlainClone(dir, false, &git.CloneOptions{
+		URL:               url,
+		ReferenceName:     plumbing.NewBranchReferenceName(ref),
+		SingleBranch:      true,
+		Depth:             1,
+		RecurseSubmodules: git.DefaultSubmoduleRecursionDepth,
+	})
+	if err == nil {
+		return nil
+	}
+
+	// ref isn't a branch tip: it may be a tag or a commit that isn't reachable from the
+	// default branch's tip commit, so this fallback clone must not be depth-limited or
+	// restricted to a single branch, or the checkout below can never find it.
+	_ = os.RemoveAll(dir)
+	repo, err := git.PlainClone(dir, false, &git.CloneOptions{
+		URL:               url,
+		RecurseSubmodules: git.DefaultSubmoduleRecursionDepth,
+		Tags:              git.AllTags,
+	})
+	if err != nil {
+		return err
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		return err
+	}
+	if err = wt.Checkout(&git.CheckoutOptions{Hash: plumbing.NewHash(ref)}); err == nil {
+		return nil
+	}
+	return wt.Checkout(&git.CheckoutOptions{Branch: plumbing.NewTagReferenceName(ref)})
+}
+
+func gitCloneDirName(url string) string {
+	return strings.TrimSuffix(path.Base(url), ".git")
+}
+
+func readGoModModulePath(goModPath string) (string, error) {
+	buf, err := os.ReadFile(goModPath)
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(string(buf), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "module ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "module")), nil
+		}
+	}
+	return "", fmt.Errorf("no module declaration found in %s", goModPath)
+}
+
+// gitPseudoVersion builds a Go-module-style pseudo-version from a git ref when the
+// plugin source doesn't specify one explicitly. It omits the leading "v": callers (e.g.
+// createMainGoFile's `go mod edit -replace` line) already prepend "v" to p.Version
+// themselves.
+func gitPseudoVersion(ref string) string {
+	if len(ref) > 12 {
+		ref = ref[:12]
+	}
+	return "0.0.0-" + ref
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseGitPluginSource(t *testing.T) {
+	cases := []struct {
+		raw                          string
+		wantURL, wantRef, wantSubdir string
+	}{
+		{"git+https://github.com/answerdev/github-connector.git@v1.0.0",
+			"https://github.com/answerdev/github-connector.git", "v1.0.0", ""},
+		{"git+https://github.com/answerdev/plugins.git@main#connectors/github",
+			"https://github.com/answerdev/plugins.git", "main", "connectors/github"},
+	}
+	for _, c := range cases {
+		p := parseGitPluginSource(c.raw)
+		if p.GitURL != c.wantURL || p.GitRef != c.wantRef || p.GitSubdir != c.wantSubdir {
+			t.Errorf("parseGitPluginSource(%q) = {URL:%q Ref:%q Subdir:%q}, want {URL:%q Ref:%q Subdir:%q}",
+				c.raw, p.GitURL, p.GitRef, p.GitSubdir, c.wantURL, c.wantRef, c.wantSubdir)
+		}
+	}
+}
+
+func TestGitCloneDirName(t *testing.T) {
+	cases := []struct{ url, want string }{
+		{"https://github.com/answerdev/github-connector.git", "github-connector"},
+		{"https://github.com/answerdev/github-connector", "github-connector"},
+	}
+	for _, c := range cases {
+		if got := gitCloneDirName(c.url); got != c.want {
+			t.Errorf("gitCloneDirName(%q) = %q, want %q", c.url, got, c.want)
+		}
+	}
+}
+
+func TestGitPseudoVersion(t *testing.T) {
+	got := gitPseudoVersion("abcdef1234567890")
+	want := "0.0.0-abcdef123456"
+	if got != want {
+		t.Errorf("gitPseudoVersion() = %q, want %q (no leading v: createMainGoFile's replace line adds its own)", got, want)
+	}
+}
+
+func TestReadGoModModulePath(t *testing.T) {
+	dir := t.TempDir()
+	goMod := "module github.com/answerdev/github-connector\n\ngo 1.21\n"
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(goMod), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := readGoModModulePath(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		t.Fatalf("readGoModModulePath() error = %v", err)
+	}
+	if want := "github.com/answerdev/github-connector"; got != want {
+		t.Errorf("readGoModModulePath() = %q, want %q", got, want)
+	}
+}
+
+func TestReadGoModModulePath_NoModuleDeclaration(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("go 1.21\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := readGoModModulePath(filepath.Join(dir, "go.mod")); err == nil {
+		t.Fatal("expected an error for a go.mod with no module declaration")
+	}
+}
@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeI18nFixture lays out buildingMaterial.tmpDir as mergeI18nFiles expects it: a
+// vendored original Answer i18n directory plus, for each plugin, a vendored i18n
+// directory under vendor/<plugin>/i18n.
+func writeI18nFixture(t *testing.T, originalYAML string, pluginYAML map[string]string) *buildingMaterial {
+	t.Helper()
+	tmpDir := t.TempDir()
+
+	originalDir := filepath.Join(tmpDir, "vendor/github.com/answerdev/answer/i18n")
+	if err := os.MkdirAll(originalDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(originalDir, "en_US.yaml"), []byte(originalYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var plugins []*pluginInfo
+	for pluginName, yamlContent := range pluginYAML {
+		pluginDir := filepath.Join(tmpDir, "vendor", pluginName, "i18n")
+		if err := os.MkdirAll(pluginDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(pluginDir, "en_US.yaml"), []byte(yamlContent), 0644); err != nil {
+			t.Fatal(err)
+		}
+		plugins = append(plugins, &pluginInfo{Name: pluginName})
+	}
+
+	return &buildingMaterial{tmpDir: tmpDir, plugins: plugins}
+}
+
+func TestMergeI18nFiles_DeepMerge(t *testing.T) {
+	b := writeI18nFixture(t,
+		"backend:\n  existing:\n    other: hello\n",
+		map[string]string{
+			"github.com/answerdev/github-connector": "plugin:\n  github-connector:\n    backend:\n      info:\n        name:\n          other: GitHub Connector\n",
+		},
+	)
+
+	if err := mergeI18nFiles(b); err != nil {
+		t.Fatalf("mergeI18nFiles() error = %v", err)
+	}
+
+	out, err := os.ReadFile(filepath.Join(b.tmpDir, "vendor/github.com/answerdev/answer/i18n/en_US.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	merged := string(out)
+	if !strings.Contains(merged, "existing:") {
+		t.Errorf("merged file lost the original backend.existing key:\n%s", merged)
+	}
+	if !strings.Contains(merged, "github-connector:") || !strings.Contains(merged, "GitHub Connector") {
+		t.Errorf("merged file is missing the plugin's translations:\n%s", merged)
+	}
+}
+
+func TestMergeI18nFiles_RejectsWrongSlugNamespace(t *testing.T) {
+	b := writeI18nFixture(t,
+		"backend: {}\n",
+		map[string]string{
+			// github.com/answerdev/github-connector's slug is "github-connector", not
+			// "someone-elses-plugin".
+			"github.com/answerdev/github-connector": "plugin:\n  someone-elses-plugin:\n    backend:\n      info:\n        name:\n          other: x\n",
+		},
+	)
+
+	if err := mergeI18nFiles(b); err == nil {
+		t.Fatal("expected an error when a plugin declares translations under a slug that isn't its own")
+	}
+}
+
+func TestMergeI18nFiles_ConflictBetweenPlugins(t *testing.T) {
+	// Two distinct plugin modules that happen to resolve to the same slug both declare
+	// translations under that slug in the same file - this must be flagged as a conflict.
+	pluginYAML := map[string]string{
+		"github.com/orgA/connector": "plugin:\n  connector:\n    backend:\n      info:\n        name:\n          other: A\n",
+		"github.com/orgB/connector": "plugin:\n  connector:\n    backend:\n      info:\n        name:\n          other: B\n",
+	}
+
+	t.Run("warns but succeeds by default", func(t *testing.T) {
+		b := writeI18nFixture(t, "backend: {}\n", pluginYAML)
+		if err := mergeI18nFiles(b); err != nil {
+			t.Fatalf("mergeI18nFiles() error = %v, want nil (conflicts only warn by default)", err)
+		}
+	})
+
+	t.Run("fails under strict mode", func(t *testing.T) {
+		b := writeI18nFixture(t, "backend: {}\n", pluginYAML)
+		b.strictI18n = true
+		if err := mergeI18nFiles(b); err == nil {
+			t.Fatal("expected an error for a translation key conflict under --strict-i18n")
+		}
+	})
+}
+
+func TestMergeI18nFiles_NoPluginTranslationsLeavesFileUntouched(t *testing.T) {
+	original := "backend:\n  existing:\n    other: hello\n"
+	b := writeI18nFixture(t, original, nil)
+
+	if err := mergeI18nFiles(b); err != nil {
+		t.Fatalf("mergeI18nFiles() error = %v", err)
+	}
+
+	out, err := os.ReadFile(filepath.Join(b.tmpDir, "vendor/github.com/answerdev/answer/i18n/en_US.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != original {
+		t.Errorf("file with no plugin contributions should be left untouched, got:\n%s", out)
+	}
+}
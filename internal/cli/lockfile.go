@@ -0,0 +1,215 @@
+package cli
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// lockfileName is the name of the lockfile BuildNewAnswer reads and writes in the output
+// directory, analogous to dagger.sum for CUE package installs.
+const lockfileName = "answer.sum"
+
+// lockEntry records the exact version and module hash a plugin was built against.
+type lockEntry struct {
+	Version string `yaml:"version"`
+	Sum     string `yaml:"sum"`
+}
+
+// lockfile pins every plugin used in a build to a specific version and checksum so that
+// repeated builds are reproducible.
+type lockfile struct {
+	Plugins map[string]lockEntry `yaml:"plugins"`
+}
+
+func loadLockfile(path string) (*lockfile, error) {
+	buf, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &lockfile{Plugins: make(map[string]lockEntry)}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	lf := &lockfile{}
+	if err = yaml.Unmarshal(buf, lf); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", lockfileName, err)
+	}
+	if lf.Plugins == nil {
+		lf.Plugins = make(map[string]lockEntry)
+	}
+	return lf, nil
+}
+
+// save writes the lockfile atomically by writing to a temp file in the same directory
+// and renaming it over the destination.
+func (l *lockfile) save(path string) (err error) {
+	out, err := yaml.Marshal(l)
+	if err != nil {
+		return err
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(path), lockfileName+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmpFile.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err = tmpFile.Write(out); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err = tmpFile.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// goModDownloadInfo mirrors the fields of `go mod download -json` that we care about.
+type goModDownloadInfo struct {
+	Version string
+	Sum     string
+}
+
+// moduleDownloadQuery builds the `module@version` argument `go mod download -json`
+// needs to resolve a plugin's checksum, applying the same `/vN` major-version path
+// suffix as versionedModulePath and defaulting to "latest" when no version is pinned.
+func moduleDownloadQuery(name, version string) string {
+	modulePath := versionedModulePath(name, version)
+	v := version
+	switch {
+	case len(v) == 0:
+		v = "latest"
+	case v != "latest" && !strings.HasPrefix(v, "v"):
+		v = "v" + v
+	}
+	return modulePath + "@" + v
+}
+
+// resolvePluginChecksum resolves the module hash (the `h1:` hash reported by
+// `go mod download -json`) for a plugin at its pinned version.
+func resolvePluginChecksum(b *buildingMaterial, p *pluginInfo) (info goModDownloadInfo, err error) {
+	cmd := b.newExecCmd("go", "mod", "download", "-json", moduleDownloadQuery(p.Name, p.Version))
+	out := &bytes.Buffer{}
+	cmd.Stdout = out
+	if err = cmd.Run(); err != nil {
+		return info, fmt.Errorf("resolve checksum for %s@%s: %w", p.Name, p.Version, err)
+	}
+	if err = json.Unmarshal(out.Bytes(), &info); err != nil {
+		return info, fmt.Errorf("parse go mod download output for %s: %w", p.Name, err)
+	}
+	return info, nil
+}
+
+// checksumLocalPlugin hashes the contents of a local plugin directory so that path-replaced
+// plugins also participate in lockfile verification. It hashes each file's path relative to
+// root rather than its absolute path, since root itself usually sits inside a per-build
+// random temp directory (e.g. a git-ref plugin's checkout) and would otherwise make the
+// checksum different on every build even when the plugin's own content hasn't changed.
+func checksumLocalPlugin(root string) (sum string, err error) {
+	h := sha256.New()
+	err = filepath.WalkDir(root, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		content, err := os.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, name)
+		if err != nil {
+			return err
+		}
+		h.Write([]byte(filepath.ToSlash(rel)))
+		h.Write(content)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// verifyPluginLockfile pins plugins to the versions recorded in answer.sum (if present)
+// and, in frozen mode, fails the build when an entry is missing or its checksum no longer
+// matches what go mod download reports.
+func verifyPluginLockfile(b *buildingMaterial) (err error) {
+	lockPath := filepath.Join(filepath.Dir(b.outputPath), lockfileName)
+	lf, err := loadLockfile(lockPath)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range b.plugins {
+		existing, ok := lf.Plugins[p.Name]
+		if ok && !b.updateLockfile && len(p.Path) == 0 {
+			// Pin to the locked version unless the caller explicitly asked for a newer one.
+			if len(p.Version) == 0 || p.Version == "latest" {
+				p.Version = existing.Version
+			}
+		}
+
+		if b.frozenLockfile {
+			if !ok {
+				return fmt.Errorf("answer build --frozen: %s has no entry in %s", p.Name, lockfileName)
+			}
+			var sum string
+			if len(p.Path) > 0 {
+				sum, err = checksumLocalPlugin(p.Path)
+			} else {
+				var info goModDownloadInfo
+				info, err = resolvePluginChecksum(b, p)
+				sum = info.Sum
+			}
+			if err != nil {
+				return err
+			}
+			if sum != existing.Sum {
+				return fmt.Errorf("answer build --frozen: checksum mismatch for %s: locked %s, got %s",
+					p.Name, existing.Sum, sum)
+			}
+		}
+	}
+	b.resolvedLockfile = lf
+	return nil
+}
+
+// updatePluginLockfile records the resolved version and checksum of every plugin used in a
+// successful build and writes answer.sum back to the output directory.
+func updatePluginLockfile(b *buildingMaterial) (err error) {
+	if b.resolvedLockfile == nil {
+		b.resolvedLockfile = &lockfile{Plugins: make(map[string]lockEntry)}
+	}
+
+	for _, p := range b.plugins {
+		var sum string
+		if len(p.Path) > 0 {
+			sum, err = checksumLocalPlugin(p.Path)
+		} else {
+			var info goModDownloadInfo
+			info, err = resolvePluginChecksum(b, p)
+			sum = info.Sum
+			if len(p.Version) == 0 {
+				p.Version = info.Version
+			}
+		}
+		if err != nil {
+			return err
+		}
+		b.resolvedLockfile.Plugins[p.Name] = lockEntry{Version: p.Version, Sum: sum}
+	}
+
+	return b.resolvedLockfile.save(filepath.Join(filepath.Dir(b.outputPath), lockfileName))
+}
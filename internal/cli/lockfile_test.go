@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLockfileSaveLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), lockfileName)
+	lf := &lockfile{Plugins: map[string]lockEntry{
+		"github.com/answerdev/github-connector": {Version: "v1.0.0", Sum: "sha256:abc"},
+	}}
+	if err := lf.save(path); err != nil {
+		t.Fatalf("save() error = %v", err)
+	}
+
+	loaded, err := loadLockfile(path)
+	if err != nil {
+		t.Fatalf("loadLockfile() error = %v", err)
+	}
+	got := loaded.Plugins["github.com/answerdev/github-connector"]
+	if got.Version != "v1.0.0" || got.Sum != "sha256:abc" {
+		t.Errorf("loadLockfile() = %+v, want {Version:v1.0.0 Sum:sha256:abc}", got)
+	}
+}
+
+func TestLoadLockfile_MissingFileReturnsEmpty(t *testing.T) {
+	lf, err := loadLockfile(filepath.Join(t.TempDir(), lockfileName))
+	if err != nil {
+		t.Fatalf("loadLockfile() error = %v", err)
+	}
+	if len(lf.Plugins) != 0 {
+		t.Errorf("expected no plugins for a missing lockfile, got %v", lf.Plugins)
+	}
+}
+
+func TestModuleDownloadQuery(t *testing.T) {
+	cases := []struct {
+		name, version, want string
+	}{
+		{"github.com/answerdev/github-connector", "", "github.com/answerdev/github-connector@latest"},
+		{"github.com/answerdev/github-connector", "1.2.3", "github.com/answerdev/github-connector@v1.2.3"},
+		{"github.com/answerdev/github-connector", "v1.2.3", "github.com/answerdev/github-connector@v1.2.3"},
+		{"github.com/answerdev/github-connector", "v2.0.0", "github.com/answerdev/github-connector/v2@v2.0.0"},
+	}
+	for _, c := range cases {
+		if got := moduleDownloadQuery(c.name, c.version); got != c.want {
+			t.Errorf("moduleDownloadQuery(%q, %q) = %q, want %q", c.name, c.version, got, c.want)
+		}
+	}
+}
+
+func TestChecksumLocalPlugin_StableAcrossDifferentRoots(t *testing.T) {
+	rootA, rootB := t.TempDir(), t.TempDir()
+	for _, root := range []string{rootA, rootB} {
+		if err := os.WriteFile(filepath.Join(root, "plugin.go"), []byte("package p\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sumA, err := checksumLocalPlugin(rootA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sumB, err := checksumLocalPlugin(rootB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sumA != sumB {
+		t.Errorf("checksumLocalPlugin() = %q for rootA, %q for rootB, want equal for byte-identical content under different roots", sumA, sumB)
+	}
+}
+
+func TestVerifyPluginLockfile_FrozenMissingEntry(t *testing.T) {
+	// outputPath is the binary's own output file path (as buildBinary writes it), not a
+	// directory - answer.sum lives alongside it, in filepath.Dir(outputPath).
+	b := &buildingMaterial{
+		outputPath:     filepath.Join(t.TempDir(), "new_answer"),
+		frozenLockfile: true,
+		plugins:        []*pluginInfo{{Name: "github.com/answerdev/github-connector", Version: "v1.0.0"}},
+	}
+	if err := verifyPluginLockfile(b); err == nil {
+		t.Fatal("expected an error when answer.sum has no entry for the plugin in frozen mode")
+	}
+}
+
+func TestVerifyPluginLockfile_PinsRemotePluginToLockedVersion(t *testing.T) {
+	outputDir := t.TempDir()
+	lf := &lockfile{Plugins: map[string]lockEntry{
+		"github.com/answerdev/github-connector": {Version: "v1.0.0", Sum: "sha256:abc"},
+	}}
+	if err := lf.save(filepath.Join(outputDir, lockfileName)); err != nil {
+		t.Fatal(err)
+	}
+
+	// frozenLockfile is left false so verifyPluginLockfile doesn't need to shell out to
+	// `go mod download` to resolve a checksum for this remote plugin.
+	p := &pluginInfo{Name: "github.com/answerdev/github-connector"}
+	b := &buildingMaterial{
+		outputPath: filepath.Join(outputDir, "new_answer"),
+		plugins:    []*pluginInfo{p},
+	}
+	if err := verifyPluginLockfile(b); err != nil {
+		t.Fatalf("verifyPluginLockfile() error = %v", err)
+	}
+	if p.Version != "v1.0.0" {
+		t.Errorf("expected plugin to be pinned to the locked version v1.0.0, got %q", p.Version)
+	}
+}
+
+func TestVerifyPluginLockfile_FrozenChecksumMismatch(t *testing.T) {
+	outputDir := t.TempDir()
+	pluginDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(pluginDir, "plugin.go"), []byte("package p\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	lf := &lockfile{Plugins: map[string]lockEntry{
+		"github.com/answerdev/github-connector": {Version: "v1.0.0", Sum: "sha256:stale"},
+	}}
+	if err := lf.save(filepath.Join(outputDir, lockfileName)); err != nil {
+		t.Fatal(err)
+	}
+
+	b := &buildingMaterial{
+		outputPath:     filepath.Join(outputDir, "new_answer"),
+		frozenLockfile: true,
+		plugins:        []*pluginInfo{{Name: "github.com/answerdev/github-connector", Path: pluginDir}},
+	}
+	if err := verifyPluginLockfile(b); err == nil {
+		t.Fatal("expected a checksum mismatch error")
+	}
+}
+
+func TestUpdatePluginLockfile_LocalPlugin(t *testing.T) {
+	outputDir := t.TempDir()
+	pluginDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(pluginDir, "plugin.go"), []byte("package p\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// As in the real pipeline, buildBinary has already written a regular file at
+	// outputPath by the time updatePluginLockfile runs.
+	outputPath := filepath.Join(outputDir, "new_answer")
+	if err := os.WriteFile(outputPath, []byte("binary"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	b := &buildingMaterial{
+		outputPath: outputPath,
+		plugins:    []*pluginInfo{{Name: "github.com/answerdev/github-connector", Version: "v1.0.0", Path: pluginDir}},
+	}
+	if err := updatePluginLockfile(b); err != nil {
+		t.Fatalf("updatePluginLockfile() error = %v", err)
+	}
+
+	lf, err := loadLockfile(filepath.Join(outputDir, lockfileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry, ok := lf.Plugins["github.com/answerdev/github-connector"]
+	if !ok {
+		t.Fatal("expected answer.sum to contain an entry for the plugin")
+	}
+	if entry.Version != "v1.0.0" || len(entry.Sum) == 0 {
+		t.Errorf("updatePluginLockfile() entry = %+v, want non-empty sum and version v1.0.0", entry)
+	}
+}
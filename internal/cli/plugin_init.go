@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/answerdev/answer/pkg/dir"
+	"github.com/answerdev/answer/pkg/writer"
+)
+
+//go:embed templates/*
+var pluginInitTemplates embed.FS
+
+// PluginKind enumerates the plugin extension points a scaffolded plugin can implement.
+type PluginKind string
+
+const (
+	PluginKindConnector    PluginKind = "connector"
+	PluginKindFilter       PluginKind = "filter"
+	PluginKindStorage      PluginKind = "storage"
+	PluginKindSearch       PluginKind = "search"
+	PluginKindNotification PluginKind = "notification"
+)
+
+var pluginNamePattern = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
+// PluginInitOptions describes the plugin project InitPlugin should scaffold.
+type PluginInitOptions struct {
+	// ModulePath is the Go module path of the new plugin, e.g. github.com/answerdev/github-connector
+	ModulePath string
+	// Kind is the extension point the plugin implements
+	Kind PluginKind
+}
+
+// InitPlugin scaffolds a new plugin project in outputDir, mirroring the layout BuildNewAnswer
+// expects: a go.mod, a plugin.go that registers against github.com/answerdev/answer/plugin,
+// an i18n/en_US.yaml skeleton for mergeI18nFiles, a plugin_test.go, and a README.md.
+func InitPlugin(outputDir string, opts PluginInitOptions) (err error) {
+	if len(opts.ModulePath) == 0 {
+		return fmt.Errorf("module path is required")
+	}
+	switch opts.Kind {
+	case PluginKindConnector, PluginKindFilter, PluginKindStorage, PluginKindSearch, PluginKindNotification:
+	default:
+		return fmt.Errorf("unsupported plugin kind: %s", opts.Kind)
+	}
+
+	if err = dir.CreateDirIfNotExist(outputDir); err != nil {
+		return err
+	}
+
+	slug := pluginSlug(opts.ModulePath)
+	data := map[string]any{
+		"module_path":    opts.ModulePath,
+		"plugin_name":    pluginStructName(slug),
+		"plugin_slug":    slug,
+		"plugin_package": pluginPackageName(slug),
+		"plugin_kind":    string(opts.Kind),
+	}
+
+	files := map[string]string{
+		"templates/plugin_init/go.mod.tpl":          "go.mod",
+		"templates/plugin_init/plugin.go.tpl":       "plugin.go",
+		"templates/plugin_init/plugin_test.go.tpl":  "plugin_test.go",
+		"templates/plugin_init/README.md.tpl":       "README.md",
+		"templates/plugin_init/i18n_en_US.yaml.tpl": filepath.Join("i18n", "en_US.yaml"),
+	}
+
+	for tplName, targetName := range files {
+		if err = renderPluginTemplate(tplName, filepath.Join(outputDir, targetName), data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func renderPluginTemplate(tplName, targetPath string, data map[string]any) (err error) {
+	raw, err := pluginInitTemplates.ReadFile(tplName)
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := template.New(filepath.Base(tplName)).Parse(string(raw))
+	if err != nil {
+		return err
+	}
+
+	rendered := &bytes.Buffer{}
+	if err = tmpl.Execute(rendered, data); err != nil {
+		return err
+	}
+
+	if err = dir.CreateDirIfNotExist(filepath.Dir(targetPath)); err != nil {
+		return err
+	}
+	return writer.WriteFile(targetPath, rendered.String())
+}
+
+// pluginSlug derives a plugin slug name from the last path element of a module path.
+func pluginSlug(modulePath string) string {
+	parts := strings.Split(modulePath, "/")
+	return parts[len(parts)-1]
+}
+
+// pluginStructName converts a plugin slug like "github-connector" into an exported
+// Go identifier like GithubConnector, suitable for use as the registered struct name.
+func pluginStructName(slug string) string {
+	words := pluginNamePattern.Split(slug, -1)
+	var b strings.Builder
+	for _, w := range words {
+		if len(w) == 0 {
+			continue
+		}
+		b.WriteString(strings.ToUpper(w[:1]))
+		b.WriteString(w[1:])
+	}
+	return b.String()
+}
+
+// pluginPackageName converts a plugin slug like "github-connector" into a lowercase Go
+// package name like "githubconnector". The scaffolded plugin.go must not be `package
+// main`: BuildNewAnswer's generated main.go consumes every plugin via a blank import of
+// its module path, and blank-importing a main package is a compile error.
+func pluginPackageName(slug string) string {
+	return strings.ToLower(pluginStructName(slug))
+}
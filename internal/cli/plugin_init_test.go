@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInitPlugin(t *testing.T) {
+	outputDir := filepath.Join(t.TempDir(), "github-connector")
+
+	err := InitPlugin(outputDir, PluginInitOptions{
+		ModulePath: "github.com/answerdev/github-connector",
+		Kind:       PluginKindConnector,
+	})
+	if err != nil {
+		t.Fatalf("InitPlugin() error = %v", err)
+	}
+
+	for _, name := range []string{"go.mod", "plugin.go", "plugin_test.go", "README.md", filepath.Join("i18n", "en_US.yaml")} {
+		if _, err := os.Stat(filepath.Join(outputDir, name)); err != nil {
+			t.Errorf("expected %s to be created: %v", name, err)
+		}
+	}
+
+	pluginGo, err := os.ReadFile(filepath.Join(outputDir, "plugin.go"))
+	if err != nil {
+		t.Fatalf("read plugin.go: %v", err)
+	}
+	if strings.Contains(string(pluginGo), "package main") {
+		t.Errorf("plugin.go must not be package main: it is blank-imported by BuildNewAnswer's generated main.go, " +
+			"and blank-importing a main package is a compile error")
+	}
+	if !strings.Contains(string(pluginGo), "package githubconnector") {
+		t.Errorf("plugin.go should declare package githubconnector, got:\n%s", pluginGo)
+	}
+}
+
+func TestInitPlugin_RejectsUnknownKind(t *testing.T) {
+	err := InitPlugin(t.TempDir(), PluginInitOptions{
+		ModulePath: "github.com/answerdev/github-connector",
+		Kind:       PluginKind("bogus"),
+	})
+	if err == nil {
+		t.Fatal("expected an error for an unsupported plugin kind")
+	}
+}
+
+func TestPluginPackageName(t *testing.T) {
+	cases := map[string]string{
+		"github-connector": "githubconnector",
+		"slack_notifier":   "slacknotifier",
+		"search":           "search",
+	}
+	for slug, want := range cases {
+		if got := pluginPackageName(slug); got != want {
+			t.Errorf("pluginPackageName(%q) = %q, want %q", slug, got, want)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package cli
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/answerdev/answer/pkg/dir"
+	"github.com/answerdev/answer/pkg/writer"
+)
+
+// ReleaseTarget is a single GOOS/GOARCH pair to cross-compile. Cgo defaults to "0" when
+// nil, since cross-compiling with cgo generally requires a matching C toolchain.
+type ReleaseTarget struct {
+	GOOS   string
+	GOARCH string
+	Cgo    *string
+}
+
+// ReleaseOptions configures BuildReleaseArtifacts.
+type ReleaseOptions struct {
+	Targets []ReleaseTarget
+	// OutputDir is where packaged archives and the SHA256SUMS manifest are written,
+	// e.g. `answer build --release --targets ... --output-dir dist/`.
+	OutputDir string
+	// SignKeyPath, if set, is a raw ed25519 private key used to sign SHA256SUMS.
+	SignKeyPath string
+}
+
+// BuildReleaseArtifacts builds answer once per ReleaseTarget from a single vendored
+// tree, reusing buildingMaterial.tmpDir across targets, and packages each resulting
+// binary into a .tar.gz (or .zip on Windows targets) archive named
+// answer_<version>_<os>_<arch>.<ext>, alongside a SHA256SUMS manifest that is optionally
+// signed with an ed25519 key.
+func BuildReleaseArtifacts(plugins []string, originalAnswerInfo OriginalAnswerInfo, opts BuildOptions,
+	releaseOpts ReleaseOptions) (artifacts []string, err error) {
+	if len(releaseOpts.Targets) == 0 {
+		return nil, fmt.Errorf("no release targets specified")
+	}
+	if err = dir.CreateDirIfNotExist(releaseOpts.OutputDir); err != nil {
+		return nil, err
+	}
+
+	builder := newAnswerBuilder("", plugins, originalAnswerInfo, opts)
+	builder.DoTask("cloneGitPlugins", cloneGitPlugins)
+	builder.DoTask("verifyPluginLockfile", verifyPluginLockfile)
+	builder.DoTask("createMainGoFile", createMainGoFile)
+	builder.DoTask("downloadGoModFile", downloadGoModFile)
+	builder.DoTask("mergeI18nFiles", mergeI18nFiles)
+	builder.DoTask("replaceNecessaryFile", replaceNecessaryFile)
+	if builder.BuildError != nil {
+		return nil, builder.BuildError
+	}
+	material := builder.buildingMaterial
+	defer os.RemoveAll(material.tmpDir)
+
+	sums := make(map[string]string)
+	for _, target := range releaseOpts.Targets {
+		binName := "answer"
+		if target.GOOS == "windows" {
+			binName += ".exe"
+		}
+		binPath := filepath.Join(material.tmpDir, fmt.Sprintf("release-%s-%s", target.GOOS, target.GOARCH), binName)
+		if err = buildBinaryForTarget(material, target, binPath); err != nil {
+			return nil, fmt.Errorf("build %s/%s: %w", target.GOOS, target.GOARCH, err)
+		}
+
+		archiveName := fmt.Sprintf("answer_%s_%s_%s", originalAnswerInfo.Version, target.GOOS, target.GOARCH)
+		var archivePath string
+		if target.GOOS == "windows" {
+			archivePath = filepath.Join(releaseOpts.OutputDir, archiveName+".zip")
+			err = packageZip(archivePath, binPath, binName)
+		} else {
+			archivePath = filepath.Join(releaseOpts.OutputDir, archiveName+".tar.gz")
+			err = packageTarGz(archivePath, binPath, binName)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("package %s/%s: %w", target.GOOS, target.GOARCH, err)
+		}
+
+		sum, err := sha256File(archivePath)
+		if err != nil {
+			return nil, err
+		}
+		sums[filepath.Base(archivePath)] = sum
+		artifacts = append(artifacts, archivePath)
+	}
+
+	manifestPath := filepath.Join(releaseOpts.OutputDir, "SHA256SUMS")
+	if err = writeSHA256SumsManifest(manifestPath, sums); err != nil {
+		return nil, err
+	}
+	artifacts = append(artifacts, manifestPath)
+
+	if len(releaseOpts.SignKeyPath) > 0 {
+		var sigPath string
+		sigPath, err = signManifest(manifestPath, releaseOpts.SignKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("sign SHA256SUMS: %w", err)
+		}
+		artifacts = append(artifacts, sigPath)
+	}
+
+	return artifacts, nil
+}
+
+// buildBinaryForTarget mirrors buildBinary but sets GOOS/GOARCH/CGO_ENABLED on the
+// subprocess environment and writes to an arbitrary outputPath rather than
+// buildingMaterial.outputPath, so the same vendored tree can be reused across targets.
+func buildBinaryForTarget(b *buildingMaterial, target ReleaseTarget, outputPath string) error {
+	versionInfo := b.originalAnswerInfo
+	cmdPkg := "github.com/answerdev/answer/cmd"
+	ldflags := fmt.Sprintf("-X %s.Version=%s -X %s.Revision=%s -X %s.Time=%s",
+		cmdPkg, versionInfo.Version, cmdPkg, versionInfo.Revision, cmdPkg, versionInfo.Time)
+
+	cmd := b.newExecCmd("go", "build", "-ldflags", ldflags, "-o", outputPath, ".")
+	cgo := "0"
+	if target.Cgo != nil {
+		cgo = *target.Cgo
+	}
+	cmd.Env = append(os.Environ(),
+		"GOOS="+target.GOOS,
+		"GOARCH="+target.GOARCH,
+		"CGO_ENABLED="+cgo,
+	)
+	return cmd.Run()
+}
+
+func packageTarGz(archivePath, srcFile, nameInArchive string) (err error) {
+	out, err := os.Create(archivePath)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	gz := gzip.NewWriter(out)
+	defer gz.Close()
+	tw := tar.NewWriter(gz)
+	defer tw.Close()
+
+	info, err := os.Stat(srcFile)
+	if err != nil {
+		return err
+	}
+	hdr, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return err
+	}
+	hdr.Name = nameInArchive
+	if err = tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+
+	f, err := os.Open(srcFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+func packageZip(archivePath, srcFile, nameInArchive string) (err error) {
+	out, err := os.Create(archivePath)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	zw := zip.NewWriter(out)
+	defer zw.Close()
+
+	w, err := zw.Create(nameInArchive)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(srcFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return err
+}
+
+func sha256File(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func writeSHA256SumsManifest(path string, sums map[string]string) error {
+	names := make([]string, 0, len(sums))
+	for name := range sums {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s  %s\n", sums[name], name)
+	}
+	return writer.WriteFile(path, b.String())
+}
+
+// signManifest signs path with a raw ed25519 private key and writes the signature
+// alongside it as path + ".sig".
+func signManifest(path, keyPath string) (sigPath string, err error) {
+	keyBytes, err := os.ReadFile(keyPath)
+	if err != nil {
+		return "", err
+	}
+	if len(keyBytes) != ed25519.PrivateKeySize {
+		return "", fmt.Errorf("signing key at %s is not a raw ed25519 private key", keyPath)
+	}
+
+	manifest, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	sig := ed25519.Sign(ed25519.PrivateKey(keyBytes), manifest)
+	sigPath = path + ".sig"
+	return sigPath, writer.WriteFile(sigPath, string(sig))
+}
@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce is how long BuildNewAnswerWatch waits for a burst of filesystem events
+// to settle before triggering a rebuild.
+const watchDebounce = 300 * time.Millisecond
+
+// WatchOptions configures BuildNewAnswerWatch's rebuild loop.
+type WatchOptions struct {
+	// Exec runs the freshly built binary after the initial build and every successful
+	// rebuild, killing and restarting it each time.
+	Exec bool
+	// Args are passed to the executed binary when Exec is true.
+	Args []string
+}
+
+// BuildNewAnswerWatch runs an initial BuildNewAnswer and then watches the local paths
+// declared by path-replaced plugins (pluginInfo.Path), rebuilding on change. It blocks
+// until the watcher is closed or an unrecoverable error occurs; it only returns on setup
+// failure, not on a failed rebuild, which is reported to stdout instead.
+func BuildNewAnswerWatch(outputPath string, plugins []string, originalAnswerInfo OriginalAnswerInfo,
+	opts BuildOptions, watchOpts WatchOptions) (err error) {
+	builder := newAnswerBuilder(outputPath, plugins, originalAnswerInfo, opts)
+	builder.DoTask("cloneGitPlugins", cloneGitPlugins)
+	builder.DoTask("verifyPluginLockfile", verifyPluginLockfile)
+	builder.DoTask("createMainGoFile", createMainGoFile)
+	builder.DoTask("downloadGoModFile", downloadGoModFile)
+	builder.DoTask("mergeI18nFiles", mergeI18nFiles)
+	builder.DoTask("replaceNecessaryFile", replaceNecessaryFile)
+	builder.DoTask("buildBinary", buildBinary)
+	builder.DoTask("updatePluginLockfile", updatePluginLockfile)
+	recordPluginVersions(builder.buildingMaterial)
+	writeBuildReport(builder.buildingMaterial)
+	if builder.BuildError != nil {
+		return builder.BuildError
+	}
+	material := builder.buildingMaterial
+	defer os.RemoveAll(material.tmpDir)
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	watched := 0
+	for _, p := range material.plugins {
+		if len(p.Path) == 0 {
+			continue
+		}
+		if err = addWatchRecursive(watcher, p.Path); err != nil {
+			return fmt.Errorf("watch %s: %w", p.Path, err)
+		}
+		watched++
+	}
+	fmt.Printf("[watch] initial build succeeded, watching %d plugin path(s)\n", watched)
+
+	var running *exec.Cmd
+	if watchOpts.Exec {
+		running = runBuiltBinary(material.outputPath, watchOpts.Args)
+	}
+
+	debounce := time.NewTimer(watchDebounce)
+	if !debounce.Stop() {
+		<-debounce.C
+	}
+	var pendingYAML, pendingSource bool
+
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Remove|fsnotify.Rename) == 0 {
+				continue
+			}
+			if filepath.Ext(event.Name) == ".yaml" || filepath.Ext(event.Name) == ".yml" {
+				pendingYAML = true
+			} else {
+				pendingSource = true
+			}
+			debounce.Reset(watchDebounce)
+
+		case watchErr, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			fmt.Printf("[watch] watcher error: %s\n", watchErr)
+
+		case <-debounce.C:
+			if !pendingYAML && !pendingSource {
+				continue
+			}
+			fmt.Printf("[watch] rebuilding (source changed=%v, i18n changed=%v)\n", pendingSource, pendingYAML)
+
+			rebuild := &answerBuilder{buildingMaterial: material}
+			rebuild.DoTask("createMainGoFile", createMainGoFile)
+			if pendingSource || pendingYAML {
+				// `go mod vendor` is what actually copies a path-replaced plugin's current
+				// files into tmpDir/vendor/..., so it must also run on a pure i18n-yaml
+				// edit or mergeI18nFiles below would merge the stale, un-revendored copy.
+				rebuild.DoTask("downloadGoModFile", downloadGoModFile)
+			}
+			if pendingYAML {
+				rebuild.DoTask("mergeI18nFiles", mergeI18nFiles)
+			}
+			rebuild.DoTask("replaceNecessaryFile", replaceNecessaryFile)
+			rebuild.DoTask("buildBinary", buildBinary)
+			writeBuildReport(material)
+
+			if rebuild.BuildError != nil {
+				fmt.Printf("[watch] rebuild failed: %s\n", rebuild.BuildError)
+			} else {
+				fmt.Printf("[watch] rebuild succeeded\n")
+				if watchOpts.Exec {
+					if running != nil && running.Process != nil {
+						_ = running.Process.Kill()
+					}
+					running = runBuiltBinary(material.outputPath, watchOpts.Args)
+				}
+			}
+			pendingYAML, pendingSource = false, false
+		}
+	}
+}
+
+// addWatchRecursive adds dir and every subdirectory beneath it to watcher, since
+// fsnotify does not watch directories recursively on its own.
+func addWatchRecursive(watcher *fsnotify.Watcher, dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return watcher.Add(path)
+		}
+		return nil
+	})
+}
+
+// runBuiltBinary starts the built answer binary in the background, wired to the
+// current process's stdout/stderr.
+func runBuiltBinary(binaryPath string, args []string) *exec.Cmd {
+	cmd := exec.Command(binaryPath, args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		fmt.Printf("[watch] failed to start %s: %s\n", binaryPath, err)
+		return nil
+	}
+	return cmd
+}